@@ -17,13 +17,17 @@ package socketclient
 import (
 	"bufio"
 	"bytes"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -50,8 +54,51 @@ var (
 	MaxWaitReady = time.Second * 10
 	// ClientName is used for identifying client in socket registration
 	ClientName = "govppsock"
+
+	// DefaultReadBufferSize is a buffer size for the New function of a
+	// pool passed to SetReadBufferPool. The client creates no pool of its
+	// own and does not read this value.
+	DefaultReadBufferSize = 4096
 )
 
+// ErrOverflow is passed to the overflow hook (see SetOverflowHook) in place
+// of dispatching a message when the inflight limit set by
+// SetInflightLimit has been reached.
+var ErrOverflow = errors.New("socketclient: dispatch overflow, message dropped")
+
+// ErrReconnected is passed to the hook installed by SetOnReconnectError for
+// every request that was still outstanding when the connection was lost
+// and was not resent: because ReconnectPolicy.ReplayPending is false,
+// because resending it failed, or because reconnection was given up. The
+// request was never acknowledged and the core govpp layer must resend it
+// (or give up on it) itself.
+var ErrReconnected = errors.New("socketclient: connection was reconnected, pending request was not acknowledged")
+
+// ReconnectPolicy enables automatic reconnection when set via
+// SetReconnectPolicy; reconnection is disabled (opt-in) by default.
+type ReconnectPolicy struct {
+	// Backoff is the delay before the first reconnect attempt; if
+	// MaxBackoff is set, it doubles after every failed attempt, up to
+	// MaxBackoff. DefaultConnectTimeout is used when Backoff is <= 0.
+	Backoff time.Duration
+	// MaxBackoff caps the exponential backoff delay between attempts.
+	// When zero, the delay stays at Backoff and does not grow.
+	MaxBackoff time.Duration
+	// MaxAttempts bounds how many reconnect attempts are made before
+	// giving up and letting readerLoop exit. 0 means unlimited.
+	MaxAttempts int
+	// ReplayPending controls what happens to requests sent via SendMsg
+	// that were still outstanding when the connection was lost. If true,
+	// they are resent (with ClientIndex updated to the new session) once
+	// reconnection succeeds. If false (the default), they are instead
+	// reported via the hook installed by SetOnReconnectError with
+	// ErrReconnected, leaving it to the core govpp layer to resend them.
+	// Either way, a multipart dump request (*_dump/*_details) that is
+	// still in progress when the link drops is tracked by its first reply
+	// only, not its terminating one — see the ackPending doc comment.
+	ReplayPending bool
+}
+
 var (
 	// Debug is global variable that determines debug mode
 	Debug = os.Getenv("DEBUG_GOVPP_SOCK") != ""
@@ -89,15 +136,109 @@ const socketMissing = `
 var warnOnce sync.Once
 
 func (c *vppClient) printMissingSocketMsg() {
-	fmt.Fprintf(os.Stderr, socketMissing, c.sockAddr)
+	fmt.Fprintf(os.Stderr, socketMissing, c.dialer.Address)
+}
+
+// Network identifies the transport used to reach the VPP binapi socket.
+type Network string
+
+const (
+	// NetworkUnix dials a local Unix domain socket (the default, as used by VPP's socksvr).
+	NetworkUnix Network = "unix"
+	// NetworkTCP dials a plain TCP connection, e.g. to reach VPP on a remote host.
+	NetworkTCP Network = "tcp"
+	// NetworkTLS dials a TLS-wrapped TCP connection using Dialer.TLSConfig.
+	NetworkTLS Network = "tls"
+)
+
+// Dialer describes how to reach the VPP binapi socket. It is built
+// automatically from the address passed to NewVppClient, but can also be
+// set explicitly via SetDialer, e.g. to supply a TLSConfig.
+type Dialer struct {
+	// Network is the transport to dial: NetworkUnix, NetworkTCP or NetworkTLS.
+	Network Network
+	// Address is the dial target: a socket path for NetworkUnix, or a
+	// host:port for NetworkTCP/NetworkTLS.
+	Address string
+	// TLSConfig supplies the client TLS configuration (certificates, root
+	// CAs, server name, ...) and is only used when Network is NetworkTLS.
+	TLSConfig *tls.Config
+	// Timeout bounds the dial itself for NetworkTCP and NetworkTLS; Unix
+	// socket dials do not use it. When zero, the client's connect timeout
+	// (DefaultConnectTimeout unless changed via SetConnectTimeout) is used.
+	Timeout time.Duration
+}
+
+// parseDialAddress interprets addr as either a plain Unix socket path
+// (the legacy behavior) or a URL of the form unix://, tcp:// or tls://.
+func parseDialAddress(addr string) (*Dialer, error) {
+	if !strings.Contains(addr, "://") {
+		return &Dialer{Network: NetworkUnix, Address: addr}, nil
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VPP API socket address %q: %v", addr, err)
+	}
+	switch Network(u.Scheme) {
+	case NetworkUnix:
+		path := u.Path
+		if path == "" {
+			path = u.Opaque
+		}
+		return &Dialer{Network: NetworkUnix, Address: path}, nil
+	case NetworkTCP, NetworkTLS:
+		return &Dialer{Network: Network(u.Scheme), Address: u.Host}, nil
+	default:
+		return nil, fmt.Errorf("unsupported VPP API socket address scheme: %q", u.Scheme)
+	}
+}
+
+// dial opens the connection described by d. defaultTimeout is used when
+// d.Timeout is unset.
+func (d *Dialer) dial(defaultTimeout time.Duration) (net.Conn, error) {
+	timeout := d.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	switch d.Network {
+	case NetworkTCP:
+		return net.DialTimeout("tcp", d.Address, timeout)
+	case NetworkTLS:
+		return tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", d.Address, d.TLSConfig)
+	default:
+		return dialUnix(d.Address)
+	}
+}
+
+// dialUnix connects to a Unix domain socket, falling back to the
+// unixpacket socket type for backwards compatibility with VPP<=19.04.
+func dialUnix(sockAddr string) (net.Conn, error) {
+	addr := &net.UnixAddr{Name: sockAddr, Net: "unix"}
+
+	conn, err := net.DialUnix("unix", nil, addr)
+	if err != nil {
+		if strings.Contains(err.Error(), "wrong type for socket") {
+			addr.Net = "unixpacket"
+			Log.Debugf("%s, retrying connect with type unixpacket", err)
+			conn, err = net.DialUnix("unixpacket", nil, addr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return conn, nil
 }
 
 type vppClient struct {
 	sockAddr string
+	dialer   *Dialer
+	dialErr  error
 
-	conn   *net.UnixConn
-	reader *bufio.Reader
-	writer *bufio.Writer
+	conn       net.Conn
+	reader     *bufio.Reader
+	writer     *bufio.Writer
+	terminated bool         // set once reconnect gives up; makes Disconnect idempotent
+	connMu     sync.RWMutex // guards conn/reader/writer/terminated while reconnect swaps them
 
 	connectTimeout    time.Duration
 	disconnectTimeout time.Duration
@@ -108,16 +249,67 @@ type vppClient struct {
 	sockDelMsgId uint16
 	writeMu      sync.Mutex
 
+	bufPool       *sync.Pool
+	dispatchSem   chan struct{}
+	dispatchMu    sync.Mutex
+	dispatchChain map[uint32]chan struct{}
+	inflightSem   chan struct{}
+	overflowHook  func(msgID uint16, err error)
+	stats         Stats
+
+	reconnectPolicy  *ReconnectPolicy
+	onReconnect      func()
+	onReconnectError func(context uint32, err error)
+	pendingMu        sync.Mutex
+	pending          map[uint32][]byte
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
 
+// Stats reports read-path metrics accumulated since the client was created.
+// Obtain a snapshot with Stats().
+type Stats struct {
+	// BytesRead is the total number of bytes read from the socket
+	// (headers and message bodies).
+	BytesRead uint64
+	// MsgsDispatched is the number of messages successfully handed to the
+	// msg callback.
+	MsgsDispatched uint64
+	// MsgsDropped is the number of messages discarded because the
+	// inflight limit was reached and an overflow hook was set (see
+	// SetOverflowHook); without a hook, readerLoop blocks instead of
+	// dropping, so this stays 0.
+	MsgsDropped uint64
+	// PoolHits is the number of read buffers served from the read buffer
+	// pool instead of freshly allocated.
+	PoolHits uint64
+}
+
+// Stats returns a snapshot of the read-path metrics collected so far.
+func (c *vppClient) Stats() Stats {
+	return Stats{
+		BytesRead:      atomic.LoadUint64(&c.stats.BytesRead),
+		MsgsDispatched: atomic.LoadUint64(&c.stats.MsgsDispatched),
+		MsgsDropped:    atomic.LoadUint64(&c.stats.MsgsDropped),
+		PoolHits:       atomic.LoadUint64(&c.stats.PoolHits),
+	}
+}
+
+// NewVppClient returns a VPP binapi socket client for addr, which may be
+// either a plain filesystem path to a Unix domain socket (the legacy
+// behavior) or a URL with scheme unix://, tcp:// or tls://, e.g.
+// "tcp://127.0.0.1:50000" or "tls://vpp.example.com:50000". Use SetDialer
+// to supply TLS configuration or a custom dial timeout.
 func NewVppClient(sockAddr string) *vppClient {
 	if sockAddr == "" {
 		sockAddr = DefaultSocketName
 	}
+	dialer, err := parseDialAddress(sockAddr)
 	return &vppClient{
 		sockAddr:          sockAddr,
+		dialer:            dialer,
+		dialErr:           err,
 		connectTimeout:    DefaultConnectTimeout,
 		disconnectTimeout: DefaultDisconnectTimeout,
 		cb: func(msgID uint16, data []byte) {
@@ -126,6 +318,90 @@ func NewVppClient(sockAddr string) *vppClient {
 	}
 }
 
+// SetDialer overrides the dialer used to establish the connection, e.g. to
+// provide a TLSConfig for NetworkTLS or a custom Timeout. It must be
+// called before Connect.
+func (c *vppClient) SetDialer(d *Dialer) {
+	c.dialer = d
+	c.dialErr = nil
+}
+
+// SetReadBufferPool opts into serving incoming message bodies from pool
+// instead of a fresh allocation per message. This is off by default
+// (pool nil) because a pooled buffer is recycled as soon as the msg
+// callback returns (see SetMsgCallback) — only set a pool if the
+// callback decodes synchronously and never retains the data it's given.
+func (c *vppClient) SetReadBufferPool(pool *sync.Pool) {
+	c.bufPool = pool
+}
+
+// SetDispatchConcurrency bounds how many msg callbacks may run
+// concurrently, off the reader goroutine, instead of the default of
+// running each callback synchronously in readerLoop. Delivery order is
+// preserved per Context (replies to a multipart *_dump request, which all
+// share one context, are never reordered among themselves), but messages
+// with different contexts may be delivered out of relative order. A value
+// <= 0 restores the synchronous (default) behavior, which preserves full
+// reader-order delivery.
+func (c *vppClient) SetDispatchConcurrency(n int) {
+	if n <= 0 {
+		c.dispatchSem = nil
+		return
+	}
+	c.dispatchSem = make(chan struct{}, n)
+}
+
+// SetInflightLimit sets the high-water mark of messages read from the
+// socket but not yet dispatched to the msg callback. Once the limit is
+// reached, readerLoop applies TCP-style backpressure by pausing further
+// reads until a slot frees up. If SetOverflowHook has installed a hook,
+// readerLoop instead drops the new message and invokes the hook with
+// ErrOverflow rather than blocking. A limit <= 0 disables the check
+// (unbounded, the default).
+func (c *vppClient) SetInflightLimit(n int) {
+	if n <= 0 {
+		c.inflightSem = nil
+		return
+	}
+	c.inflightSem = make(chan struct{}, n)
+}
+
+// SetOverflowHook installs a callback invoked, with ErrOverflow, in place
+// of blocking when the inflight limit set by SetInflightLimit is reached;
+// the offending message is dropped. Passing nil restores the default
+// blocking behavior.
+func (c *vppClient) SetOverflowHook(hook func(msgID uint16, err error)) {
+	c.overflowHook = hook
+}
+
+// SetReconnectPolicy opts the client into automatic reconnection: once
+// readerLoop observes the connection was closed, it re-dials, re-runs the
+// sockclnt_create handshake and rebuilds msgTable, failing fast if VPP
+// came back with a different message table (e.g. after an upgrade). A
+// write failure (e.g. from SendMsg) is not itself a reconnect trigger; it
+// surfaces as a normal returned error, and reconnection kicks in once the
+// subsequent read also observes the closed connection. Passing nil
+// disables reconnection, which is the default.
+func (c *vppClient) SetReconnectPolicy(policy *ReconnectPolicy) {
+	c.reconnectPolicy = policy
+}
+
+// SetOnReconnect installs a callback invoked after every successful
+// reconnect, so upper layers can re-subscribe to events lost with the old
+// session.
+func (c *vppClient) SetOnReconnect(fn func()) {
+	c.onReconnect = fn
+}
+
+// SetOnReconnectError installs a callback invoked, with ErrReconnected, for
+// every request sent via SendMsg that was still outstanding when the
+// connection was lost and was not resent: ReconnectPolicy.ReplayPending is
+// false, the resend failed, or reconnection was given up (the last case
+// applies whatever ReplayPending says).
+func (c *vppClient) SetOnReconnectError(fn func(context uint32, err error)) {
+	c.onReconnectError = fn
+}
+
 // SetConnectTimeout sets timeout used during connecting.
 func (c *vppClient) SetConnectTimeout(t time.Duration) {
 	c.connectTimeout = t
@@ -136,36 +412,51 @@ func (c *vppClient) SetDisconnectTimeout(t time.Duration) {
 	c.disconnectTimeout = t
 }
 
+// SetMsgCallback sets the callback invoked for every received message. By
+// default data is freshly allocated per message and cb may retain it
+// however it likes. If SetReadBufferPool has opted into buffer pooling,
+// data is instead drawn from the pool and recycled for a future message
+// as soon as cb returns, so cb must decode it synchronously or copy it
+// first if it needs to outlive the call.
 func (c *vppClient) SetMsgCallback(cb adapter.MsgCallback) {
 	Log.Debug("SetMsgCallback")
 	c.cb = cb
 }
 
 func (c *vppClient) checkLegacySocket() bool {
-	if c.sockAddr == legacySocketName {
+	if c.dialer.Network != NetworkUnix || c.dialer.Address == legacySocketName {
 		return false
 	}
 	Log.Debugf("checking legacy socket: %s", legacySocketName)
 	// check if socket exists
-	if _, err := os.Stat(c.sockAddr); err == nil {
+	if _, err := os.Stat(c.dialer.Address); err == nil {
 		return false // socket exists
 	} else if !os.IsNotExist(err) {
 		return false // some other error occurred
 	}
 	// check if legacy socket exists
 	if _, err := os.Stat(legacySocketName); err == nil {
-		// legacy socket exists, update sockAddr
-		c.sockAddr = legacySocketName
+		// legacy socket exists, update dial address
+		c.dialer.Address = legacySocketName
 		return true
 	}
 	// no socket socket found
 	return false
 }
 
-// WaitReady checks socket file existence and waits for it if necessary
+// WaitReady checks socket file existence and waits for it if necessary.
+// It is a no-op for non-Unix (tcp/tls) addresses, since those have no
+// socket file to watch for.
 func (c *vppClient) WaitReady() error {
+	if c.dialErr != nil {
+		return c.dialErr
+	}
+	if c.dialer.Network != NetworkUnix {
+		return nil
+	}
+
 	// check if socket already exists
-	if _, err := os.Stat(c.sockAddr); err == nil {
+	if _, err := os.Stat(c.dialer.Address); err == nil {
 		return nil // socket exists, we are ready
 	} else if !os.IsNotExist(err) {
 		return err // some other error occurred
@@ -187,7 +478,7 @@ func (c *vppClient) WaitReady() error {
 	}()
 
 	// start directory watcher
-	if err := watcher.Add(filepath.Dir(c.sockAddr)); err != nil {
+	if err := watcher.Add(filepath.Dir(c.dialer.Address)); err != nil {
 		return err
 	}
 
@@ -198,14 +489,14 @@ func (c *vppClient) WaitReady() error {
 			if c.checkLegacySocket() {
 				return nil
 			}
-			return fmt.Errorf("timeout waiting (%s) for socket file: %s", MaxWaitReady, c.sockAddr)
+			return fmt.Errorf("timeout waiting (%s) for socket file: %s", MaxWaitReady, c.dialer.Address)
 
 		case e := <-watcher.Errors:
 			return e
 
 		case ev := <-watcher.Events:
 			Log.Debugf("watcher event: %+v", ev)
-			if ev.Name == c.sockAddr && (ev.Op&fsnotify.Create) == fsnotify.Create {
+			if ev.Name == c.dialer.Address && (ev.Op&fsnotify.Create) == fsnotify.Create {
 				// socket created, we are ready
 				return nil
 			}
@@ -214,17 +505,23 @@ func (c *vppClient) WaitReady() error {
 }
 
 func (c *vppClient) Connect() error {
-	c.checkLegacySocket()
+	if c.dialErr != nil {
+		return c.dialErr
+	}
 
-	// check if socket exists
-	if _, err := os.Stat(c.sockAddr); os.IsNotExist(err) {
-		warnOnce.Do(c.printMissingSocketMsg)
-		return fmt.Errorf("VPP API socket file %s does not exist", c.sockAddr)
-	} else if err != nil {
-		return fmt.Errorf("VPP API socket error: %v", err)
+	if c.dialer.Network == NetworkUnix {
+		c.checkLegacySocket()
+
+		// check if socket exists
+		if _, err := os.Stat(c.dialer.Address); os.IsNotExist(err) {
+			warnOnce.Do(c.printMissingSocketMsg)
+			return fmt.Errorf("VPP API socket file %s does not exist", c.dialer.Address)
+		} else if err != nil {
+			return fmt.Errorf("VPP API socket error: %v", err)
+		}
 	}
 
-	if err := c.connect(c.sockAddr); err != nil {
+	if err := c.connect(); err != nil {
 		return err
 	}
 
@@ -246,9 +543,26 @@ func (c *vppClient) Disconnect() error {
 	}
 	Log.Debugf("Disconnecting..")
 
-	close(c.quit)
+	select {
+	case <-c.quit:
+		// already closed, e.g. by a previous Disconnect call
+	default:
+		close(c.quit)
+	}
 
-	if err := c.conn.CloseRead(); err != nil {
+	c.connMu.RLock()
+	terminated := c.terminated
+	c.connMu.RUnlock()
+
+	// If reconnect already gave up, readerLoop is gone and the connection
+	// is already closed; closing it again, or sending sockclnt_delete over
+	// it, would only produce a spurious "use of closed connection" error.
+	if terminated {
+		c.wg.Wait()
+		return nil
+	}
+
+	if err := c.closeRead(); err != nil {
 		Log.Debugf("closing read failed: %v", err)
 	}
 
@@ -266,30 +580,40 @@ func (c *vppClient) Disconnect() error {
 	return nil
 }
 
-func (c *vppClient) connect(sockAddr string) error {
-	addr := &net.UnixAddr{Name: sockAddr, Net: "unix"}
+// halfCloser is implemented by connections (Unix, TCP) that support
+// closing only the read half. TLS connections do not, so closeRead falls
+// back to an immediate read deadline to unblock readerLoop.
+type halfCloser interface {
+	CloseRead() error
+}
+
+func (c *vppClient) closeRead() error {
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
 
+	if hc, ok := conn.(halfCloser); ok {
+		return hc.CloseRead()
+	}
+	return conn.SetReadDeadline(time.Now())
+}
+
+func (c *vppClient) connect() error {
 	Log.Debugf("Connecting to: %v", c.sockAddr)
 
-	conn, err := net.DialUnix("unix", nil, addr)
+	conn, err := c.dialer.dial(c.connectTimeout)
 	if err != nil {
-		// we try different type of socket for backwards compatbility with VPP<=19.04
-		if strings.Contains(err.Error(), "wrong type for socket") {
-			addr.Net = "unixpacket"
-			Log.Debugf("%s, retrying connect with type unixpacket", err)
-			conn, err = net.DialUnix("unixpacket", nil, addr)
-		}
-		if err != nil {
-			Log.Debugf("Connecting to socket %s failed: %s", addr, err)
-			return err
-		}
+		Log.Debugf("Connecting to %s failed: %s", c.sockAddr, err)
+		return err
 	}
 
+	c.connMu.Lock()
 	c.conn = conn
-	Log.Debugf("Connected to socket (local addr: %v)", c.conn.LocalAddr().(*net.UnixAddr))
-
 	c.reader = bufio.NewReader(c.conn)
 	c.writer = bufio.NewWriter(c.conn)
+	c.connMu.Unlock()
+
+	Log.Debugf("Connected to socket (local addr: %v)", conn.LocalAddr())
 
 	return nil
 }
@@ -330,11 +654,12 @@ func (c *vppClient) open() error {
 	if err := c.conn.SetReadDeadline(readDeadline); err != nil {
 		return err
 	}
-	msgReply, err := c.read()
+	msgReply, pooled, err := c.read()
 	if err != nil {
 		Log.Println("Read error:", err)
 		return err
 	}
+	defer c.putBuf(msgReply, pooled)
 	// reset read deadline
 	if err := c.conn.SetReadDeadline(time.Time{}); err != nil {
 		return err
@@ -349,30 +674,45 @@ func (c *vppClient) open() error {
 	Log.Debugf("SockclntCreateReply: Response=%v Index=%v Count=%v",
 		reply.Response, reply.Index, reply.Count)
 
-	c.clientIndex = reply.Index
-	c.msgTable = make(map[string]uint16, reply.Count)
+	msgTable := make(map[string]uint16, reply.Count)
+	var sockDelMsgId uint16
 	for _, x := range reply.MessageTable {
 		msgName := strings.Split(x.Name, "\x00")[0]
 		name := strings.TrimSuffix(msgName, "\x13")
-		c.msgTable[name] = x.Index
+		msgTable[name] = x.Index
 		if strings.HasPrefix(name, "sockclnt_delete_") {
-			c.sockDelMsgId = x.Index
+			sockDelMsgId = x.Index
 		}
 		if DebugMsgIds {
 			Log.Debugf(" - %4d: %q", x.Index, name)
 		}
 	}
 
+	// clientIndex/msgTable/sockDelMsgId are also read concurrently by
+	// GetMsgID/SendMsg/close from other goroutines, and rewritten here by
+	// reconnect after the initial Connect, so they share connMu with the
+	// conn/reader/writer swap rather than being set unguarded.
+	c.connMu.Lock()
+	c.clientIndex = reply.Index
+	c.msgTable = msgTable
+	c.sockDelMsgId = sockDelMsgId
+	c.connMu.Unlock()
+
 	return nil
 }
 
 func (c *vppClient) close() error {
 	msgCodec := new(codec.MsgCodec)
 
+	c.connMu.RLock()
+	clientIndex := c.clientIndex
+	sockDelMsgId := c.sockDelMsgId
+	c.connMu.RUnlock()
+
 	req := &SockclntDelete{
-		Index: c.clientIndex,
+		Index: clientIndex,
 	}
-	msg, err := msgCodec.EncodeMsg(req, c.sockDelMsgId)
+	msg, err := msgCodec.EncodeMsg(req, sockDelMsgId)
 	if err != nil {
 		Log.Debugln("Encode error:", err)
 		return err
@@ -390,7 +730,7 @@ func (c *vppClient) close() error {
 	if err := c.conn.SetReadDeadline(readDeadline); err != nil {
 		return err
 	}
-	msgReply, err := c.read()
+	msgReply, pooled, err := c.read()
 	if err != nil {
 		Log.Debugln("Read error:", err)
 		if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
@@ -399,6 +739,7 @@ func (c *vppClient) close() error {
 		}
 		return err
 	}
+	defer c.putBuf(msgReply, pooled)
 	// reset read deadline
 	if err := c.conn.SetReadDeadline(time.Time{}); err != nil {
 		return err
@@ -417,7 +758,9 @@ func (c *vppClient) close() error {
 
 func (c *vppClient) GetMsgID(msgName string, msgCrc string) (uint16, error) {
 	msg := msgName + "_" + msgCrc
+	c.connMu.RLock()
 	msgID, ok := c.msgTable[msg]
+	c.connMu.RUnlock()
 	if !ok {
 		return 0, &adapter.UnknownMsgError{msgName, msgCrc}
 	}
@@ -431,8 +774,35 @@ type reqHeader struct {
 }
 
 func (c *vppClient) SendMsg(context uint32, data []byte) error {
+	c.connMu.RLock()
+	clientIndex := c.clientIndex
+	c.connMu.RUnlock()
+
+	if err := encodeReqHeader(data, clientIndex, context); err != nil {
+		return err
+	}
+
+	Log.Debugf("sendMsg (%d) context=%v client=%d: data: % 02X", len(data), context, clientIndex, data)
+
+	if c.reconnectPolicy != nil {
+		c.trackPending(context, data)
+	}
+
+	if err := c.write(data); err != nil {
+		Log.Debugln("write error: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// encodeReqHeader packs a reqHeader for clientIndex/context into data[2:],
+// overwriting the placeholder header bytes set by the generated binapi
+// client. Used by SendMsg and, on reconnect, to re-target a replayed
+// request at the new session's ClientIndex.
+func encodeReqHeader(data []byte, clientIndex, context uint32) error {
 	h := &reqHeader{
-		ClientIndex: c.clientIndex,
+		ClientIndex: clientIndex,
 		Context:     context,
 	}
 	buf := new(bytes.Buffer)
@@ -440,15 +810,46 @@ func (c *vppClient) SendMsg(context uint32, data []byte) error {
 		return err
 	}
 	copy(data[2:], buf.Bytes())
+	return nil
+}
 
-	Log.Debugf("sendMsg (%d) context=%v client=%d: data: % 02X", len(data), context, c.clientIndex, data)
-
-	if err := c.write(data); err != nil {
-		Log.Debugln("write error: ", err)
-		return err
+// trackPending records an outstanding request so it can be replayed, or
+// reported via SetOnReconnectError, if the connection is lost before a
+// reply arrives. It is only called when a ReconnectPolicy is set.
+//
+// Known limitation: this layer has no notion of multipart dumps (a
+// *_dump request answered by N *_details plus a terminating control_ping
+// reply sharing one context) — see ackPending.
+func (c *vppClient) trackPending(context uint32, data []byte) {
+	c.pendingMu.Lock()
+	if c.pending == nil {
+		c.pending = make(map[uint32][]byte)
 	}
+	c.pending[context] = append([]byte(nil), data...)
+	c.pendingMu.Unlock()
+}
 
-	return nil
+// ackPending forgets an outstanding request once a reply for its context
+// has been read, so it's no longer a candidate for reconnect
+// replay/reporting.
+//
+// Known limitation: for multipart dumps, this fires on the first
+// *_details reply, not the terminating one, because the socket framing
+// gives no way to tell them apart at this layer. If the connection then
+// drops mid-dump, the request is already untracked: with
+// ReplayPending=false it is silently neither replayed nor reported, and
+// with ReplayPending=true a *different* in-flight request could
+// (harmlessly) reuse the same context. Dump reassembly and context
+// lifecycle belong to the core govpp layer, which is in a position to
+// track the terminating reply and should not rely on this adapter's
+// reconnect bookkeeping for dump requests.
+func (c *vppClient) ackPending(context uint32) {
+	if c.reconnectPolicy == nil {
+		return
+	}
+	c.pendingMu.Lock()
+	delete(c.pending, context)
+	c.pendingMu.Unlock()
 }
 
 func (c *vppClient) write(msg []byte) error {
@@ -465,26 +866,30 @@ func (c *vppClient) write(msg []byte) error {
 	c.writeMu.Lock()
 	defer c.writeMu.Unlock()
 
-	if n, err := c.writer.Write(header); err != nil {
+	c.connMu.RLock()
+	writer := c.writer
+	c.connMu.RUnlock()
+
+	if n, err := writer.Write(header); err != nil {
 		return err
 	} else {
 		Log.Debugf(" - header sent (%d/%d): % 0X", n, len(header), header)
 	}
 
-	writerSize := c.writer.Size()
+	writerSize := writer.Size()
 	for i := 0; i <= len(msg)/writerSize; i++ {
 		x := i*writerSize + writerSize
 		if x > len(msg) {
 			x = len(msg)
 		}
 		Log.Debugf(" - x=%v i=%v len=%v mod=%v", x, i, len(msg), len(msg)/writerSize)
-		if n, err := c.writer.Write(msg[i*writerSize : x]); err != nil {
+		if n, err := writer.Write(msg[i*writerSize : x]); err != nil {
 			return err
 		} else {
 			Log.Debugf(" - msg sent x=%d (%d/%d): % 0X", x, n, len(msg), msg)
 		}
 	}
-	if err := c.writer.Flush(); err != nil {
+	if err := writer.Flush(); err != nil {
 		return err
 	}
 
@@ -509,9 +914,37 @@ func (c *vppClient) readerLoop() {
 		default:
 		}
 
-		msg, err := c.read()
+		// Apply backpressure before pulling the next message off the
+		// wire: if we're at the inflight high-water mark and no overflow
+		// hook is set, block here until a dispatched message frees a
+		// slot, which pauses reads (and, transitively, the sender) the
+		// same way a full TCP receive buffer would.
+		haveSlot := true
+		if sem := c.inflightSem; sem != nil {
+			if c.overflowHook == nil {
+				select {
+				case sem <- struct{}{}:
+				case <-c.quit:
+					return
+				}
+			} else {
+				select {
+				case sem <- struct{}{}:
+				default:
+					haveSlot = false
+				}
+			}
+		}
+
+		msg, pooled, err := c.read()
 		if err != nil {
+			if haveSlot && c.inflightSem != nil {
+				<-c.inflightSem
+			}
 			if isClosedError(err) {
+				if c.reconnectPolicy != nil && c.reconnect() {
+					continue
+				}
 				return
 			}
 			Log.Debugf("read failed: %v", err)
@@ -521,52 +954,355 @@ func (c *vppClient) readerLoop() {
 		h := new(msgHeader)
 		if err := struc.Unpack(bytes.NewReader(msg), h); err != nil {
 			Log.Debugf("unpacking header failed: %v", err)
+			if haveSlot && c.inflightSem != nil {
+				<-c.inflightSem
+			}
+			c.putBuf(msg, pooled)
 			continue
 		}
 
-		Log.Debugf("recvMsg (%d) msgID=%d context=%v", len(msg), h.MsgID, h.Context)
-		c.cb(h.MsgID, msg)
+		c.ackPending(h.Context)
+
+		if !haveSlot {
+			atomic.AddUint64(&c.stats.MsgsDropped, 1)
+			c.putBuf(msg, pooled)
+			c.overflowHook(h.MsgID, ErrOverflow)
+			continue
+		}
+
+		c.dispatch(h.MsgID, h.Context, msg, pooled)
 	}
 }
 
+// reconnect re-dials after the connection was lost, per reconnectPolicy.
+// It blocks the reader goroutine for the duration of the attempts (there
+// is nothing useful to read until a new connection exists) but aborts
+// early if the client is asked to disconnect. It reports whether
+// reconnection succeeded; on success readerLoop resumes reading on the
+// fresh connection.
+func (c *vppClient) reconnect() bool {
+	policy := c.reconnectPolicy
+
+	// Disconnect closes quit before half-closing the conn, so a read error
+	// caused by Disconnect lands here with quit already closed; leave the
+	// conn open for Disconnect to send sockclnt_delete and close it.
+	select {
+	case <-c.quit:
+		return false
+	default:
+	}
+
+	// Otherwise the old conn is already dead (that's why we're here); close it now
+	// instead of leaving it to be overwritten by the next successful
+	// connect(), which would otherwise leak its fd for the duration of
+	// the reconnect attempts.
+	c.connMu.Lock()
+	dead := c.conn
+	c.connMu.Unlock()
+	if dead != nil {
+		if err := dead.Close(); err != nil {
+			Log.Debugf("reconnect: closing dead connection failed: %v", err)
+		}
+	}
+
+	backoff := policy.Backoff
+	if backoff <= 0 {
+		backoff = DefaultConnectTimeout
+	}
+
+	for attempt := 1; policy.MaxAttempts == 0 || attempt <= policy.MaxAttempts; attempt++ {
+		select {
+		case <-c.quit:
+			return false
+		case <-time.After(backoff):
+		}
+		if policy.MaxBackoff > 0 {
+			backoff *= 2
+			if backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+
+		Log.Debugf("reconnecting to %s (attempt %d)", c.sockAddr, attempt)
+
+		if c.dialer.Network == NetworkUnix {
+			c.checkLegacySocket()
+		}
+
+		if err := c.connect(); err != nil {
+			Log.Debugf("reconnect: dial failed: %v", err)
+			continue
+		}
+
+		c.connMu.RLock()
+		oldMsgTable := c.msgTable
+		c.connMu.RUnlock()
+
+		if err := c.open(); err != nil {
+			Log.Debugf("reconnect: handshake failed: %v", err)
+			c.disconnect()
+			continue
+		}
+
+		c.connMu.RLock()
+		newMsgTable := c.msgTable
+		c.connMu.RUnlock()
+
+		if err := verifyMsgTable(oldMsgTable, newMsgTable); err != nil {
+			Log.Warnf("reconnect: %v, giving up", err)
+			c.disconnect()
+			c.discardPending()
+			c.markTerminated()
+			return false
+		}
+
+		c.replayPending()
+
+		if c.onReconnect != nil {
+			c.onReconnect()
+		}
+		Log.Debugf("reconnected to %s after %d attempt(s)", c.sockAddr, attempt)
+		return true
+	}
+
+	Log.Warnf("reconnect: giving up after %d attempt(s)", policy.MaxAttempts)
+	c.discardPending()
+	c.markTerminated()
+	return false
+}
+
+// markTerminated records that reconnection has been given up on for good
+// (as opposed to readerLoop simply exiting because Disconnect asked it
+// to), so Disconnect knows the connection is already closed and must not
+// close it again.
+func (c *vppClient) markTerminated() {
+	c.connMu.Lock()
+	c.terminated = true
+	c.connMu.Unlock()
+}
+
+// verifyMsgTable fails fast if VPP came back up with a different API than
+// before the disconnect: every message name in the old table must still be
+// present in the new one with the same ID, whether or not the client ever
+// looked that message up.
+func verifyMsgTable(oldTable, newTable map[string]uint16) error {
+	for name, id := range oldTable {
+		newID, ok := newTable[name]
+		if !ok {
+			return fmt.Errorf("message %q is no longer present, VPP API appears to have changed", name)
+		}
+		if newID != id {
+			return fmt.Errorf("message %q changed ID from %d to %d, VPP API appears to have changed", name, id, newID)
+		}
+	}
+	return nil
+}
+
+// replayPending resolves requests that were outstanding when the
+// connection was lost: if ReplayPending is set, they are resent with
+// ClientIndex updated to the new session; otherwise each is reported via
+// onReconnectError with ErrReconnected.
+func (c *vppClient) replayPending() {
+	c.pendingMu.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.pendingMu.Unlock()
+
+	c.connMu.RLock()
+	clientIndex := c.clientIndex
+	c.connMu.RUnlock()
+
+	for context, data := range pending {
+		if !c.reconnectPolicy.ReplayPending {
+			if c.onReconnectError != nil {
+				c.onReconnectError(context, ErrReconnected)
+			}
+			continue
+		}
+
+		if err := encodeReqHeader(data, clientIndex, context); err != nil {
+			Log.Debugf("reconnect: re-encoding pending request (context=%v) failed: %v", context, err)
+			if c.onReconnectError != nil {
+				c.onReconnectError(context, ErrReconnected)
+			}
+			continue
+		}
+
+		c.trackPending(context, data)
+		if err := c.write(data); err != nil {
+			Log.Debugf("reconnect: replaying pending request (context=%v) failed: %v", context, err)
+			if c.onReconnectError != nil {
+				c.onReconnectError(context, ErrReconnected)
+			}
+		}
+	}
+}
+
+// discardPending reports every outstanding request via onReconnectError
+// with ErrReconnected. It is used when reconnection itself is abandoned
+// (MaxAttempts exhausted, or VPP came back with an incompatible message
+// table) so there is no successful new session left to replay requests
+// against; unlike replayPending, ReplayPending is not consulted here.
+func (c *vppClient) discardPending() {
+	c.pendingMu.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.pendingMu.Unlock()
+
+	if c.onReconnectError == nil {
+		return
+	}
+	for context := range pending {
+		c.onReconnectError(context, ErrReconnected)
+	}
+}
+
+// dispatch runs cb for a received message, releasing its inflight slot and
+// read buffer once the callback returns. If SetDispatchConcurrency
+// configured a dispatch pool, the callback runs on a pooled goroutine off
+// the reader goroutine; otherwise it runs synchronously, as it always did
+// before dispatch concurrency was introduced.
+func (c *vppClient) dispatch(msgID uint16, context uint32, msg []byte, pooled bool) {
+	run := func() {
+		Log.Debugf("recvMsg (%d) msgID=%d context=%v", len(msg), msgID, context)
+		c.cb(msgID, msg)
+		atomic.AddUint64(&c.stats.MsgsDispatched, 1)
+		if c.inflightSem != nil {
+			<-c.inflightSem
+		}
+		c.putBuf(msg, pooled)
+	}
+
+	sem := c.dispatchSem
+	if sem == nil {
+		run()
+		return
+	}
+
+	// Chain this dispatch behind the previous one for the same context,
+	// so a concurrent dispatch pool never reorders the replies of a
+	// multipart dump (which all share one context) relative to each
+	// other, even though messages with different contexts may run out of
+	// relative order.
+	prev, done := c.chainDispatch(context)
+	sem <- struct{}{}
+	go func() {
+		defer func() { <-sem }()
+		if prev != nil {
+			<-prev
+		}
+		run()
+		c.unchainDispatch(context, done)
+	}()
+}
+
+// chainDispatch registers a new "done" channel for context, returning the
+// previous one (if any) so the caller can wait on it before running the
+// callback, preserving per-context delivery order across a dispatch pool.
+func (c *vppClient) chainDispatch(context uint32) (prev, done chan struct{}) {
+	done = make(chan struct{})
+	c.dispatchMu.Lock()
+	if c.dispatchChain == nil {
+		c.dispatchChain = make(map[uint32]chan struct{})
+	}
+	prev = c.dispatchChain[context]
+	c.dispatchChain[context] = done
+	c.dispatchMu.Unlock()
+	return prev, done
+}
+
+// unchainDispatch closes done and removes it from dispatchChain if no
+// later dispatch for the same context has since replaced it.
+func (c *vppClient) unchainDispatch(context uint32, done chan struct{}) {
+	close(done)
+	c.dispatchMu.Lock()
+	if c.dispatchChain[context] == done {
+		delete(c.dispatchChain, context)
+	}
+	c.dispatchMu.Unlock()
+}
+
+// getBuf returns an n-byte buffer, preferring the read buffer pool, and
+// reports whether it came from the pool (so putBuf knows whether to
+// return it).
+func (c *vppClient) getBuf(n int) (buf []byte, pooled bool) {
+	pool := c.bufPool
+	if pool == nil {
+		return make([]byte, n), false
+	}
+	b, _ := pool.Get().([]byte)
+	if cap(b) < n {
+		// Too small for this message: hand it back to the pool for some
+		// future, smaller message instead of dropping it, and allocate
+		// fresh at this message's size. Returning pooled=true feeds that
+		// new buffer back into the pool via putBuf once the callback is
+		// done with it, growing the pool's capacity toward observed
+		// message sizes rather than permanently falling back to unpooled
+		// allocations for every message larger than the pool's buffers.
+		if b != nil {
+			pool.Put(b)
+		}
+		return make([]byte, n), true
+	}
+	atomic.AddUint64(&c.stats.PoolHits, 1)
+	return b[:n], true
+}
+
+// putBuf returns a buffer obtained from getBuf to the read buffer pool.
+func (c *vppClient) putBuf(buf []byte, pooled bool) {
+	if !pooled || c.bufPool == nil {
+		return
+	}
+	c.bufPool.Put(buf[:cap(buf)])
+}
+
 type msgheader struct {
 	Q               int    `struc:"uint64"`
 	DataLen         uint32 `struc:"uint32"`
 	GcMarkTimestamp uint32 `struc:"uint32"`
 }
 
-func (c *vppClient) read() ([]byte, error) {
+// read reads the next framed message off the socket: a fixed 16-byte
+// msgheader followed by DataLen bytes of payload. The payload buffer is
+// drawn from the read buffer pool when possible; the caller must release
+// it via putBuf once done with it.
+func (c *vppClient) read() (msg []byte, pooled bool, err error) {
 	Log.Debug(" reading next msg..")
 
+	c.connMu.RLock()
+	reader := c.reader
+	c.connMu.RUnlock()
+
 	header := make([]byte, 16)
 
-	n, err := io.ReadAtLeast(c.reader, header, 16)
+	n, err := io.ReadAtLeast(reader, header, 16)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if n == 0 {
 		Log.Debugln("zero bytes header")
-		return nil, nil
+		return nil, false, nil
 	} else if n != 16 {
 		Log.Debugf("invalid header data (%d): % 0X", n, header[:n])
-		return nil, fmt.Errorf("invalid header (expected 16 bytes, got %d)", n)
+		return nil, false, fmt.Errorf("invalid header (expected 16 bytes, got %d)", n)
 	}
 	Log.Debugf(" read header %d bytes: % 0X", n, header)
 
 	h := &msgheader{}
 	if err := struc.Unpack(bytes.NewReader(header[:]), h); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	Log.Debugf(" - decoded header: %+v", h)
 
 	msgLen := int(h.DataLen)
-	msg := make([]byte, msgLen)
+	msg, pooled = c.getBuf(msgLen)
 
-	n, err = c.reader.Read(msg)
+	n, err = reader.Read(msg)
 	if err != nil {
-		return nil, err
+		c.putBuf(msg, pooled)
+		return nil, false, err
 	}
-	Log.Debugf(" - read msg %d bytes (%d buffered) % 0X", n, c.reader.Buffered(), msg[:n])
+	Log.Debugf(" - read msg %d bytes (%d buffered) % 0X", n, reader.Buffered(), msg[:n])
 
 	if msgLen > n {
 		remain := msgLen - n
@@ -574,11 +1310,13 @@ func (c *vppClient) read() ([]byte, error) {
 		view := msg[n:]
 
 		for remain > 0 {
-			nbytes, err := c.reader.Read(view)
+			nbytes, err := reader.Read(view)
 			if err != nil {
-				return nil, err
+				c.putBuf(msg, pooled)
+				return nil, false, err
 			} else if nbytes == 0 {
-				return nil, fmt.Errorf("zero nbytes")
+				c.putBuf(msg, pooled)
+				return nil, false, fmt.Errorf("zero nbytes")
 			}
 
 			remain -= nbytes
@@ -588,9 +1326,10 @@ func (c *vppClient) read() ([]byte, error) {
 		}
 	}
 
-	Log.Debugf(" -- read done (buffered: %d)", c.reader.Buffered())
+	atomic.AddUint64(&c.stats.BytesRead, uint64(16+msgLen))
+	Log.Debugf(" -- read done (buffered: %d)", reader.Buffered())
 
-	return msg, nil
+	return msg, pooled, nil
 }
 
 func isClosedError(err error) bool {
@@ -0,0 +1,355 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socketclient
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"sync"
+	"testing"
+)
+
+func TestParseDialAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		addr     string
+		wantErr  bool
+		wantNet  Network
+		wantAddr string
+	}{
+		{
+			name:     "legacy raw path",
+			addr:     "/run/vpp/api.sock",
+			wantNet:  NetworkUnix,
+			wantAddr: "/run/vpp/api.sock",
+		},
+		{
+			name:     "unix scheme",
+			addr:     "unix:///run/vpp/api.sock",
+			wantNet:  NetworkUnix,
+			wantAddr: "/run/vpp/api.sock",
+		},
+		{
+			name:     "tcp scheme",
+			addr:     "tcp://127.0.0.1:50000",
+			wantNet:  NetworkTCP,
+			wantAddr: "127.0.0.1:50000",
+		},
+		{
+			name:     "tls scheme",
+			addr:     "tls://vpp.example.com:50000",
+			wantNet:  NetworkTLS,
+			wantAddr: "vpp.example.com:50000",
+		},
+		{
+			name:    "unsupported scheme",
+			addr:    "ftp://127.0.0.1:50000",
+			wantErr: true,
+		},
+		{
+			name:    "invalid url",
+			addr:    "tcp://%zz",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := parseDialAddress(tt.addr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseDialAddress(%q) = nil error, want error", tt.addr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDialAddress(%q) returned unexpected error: %v", tt.addr, err)
+			}
+			if d.Network != tt.wantNet {
+				t.Errorf("Network = %q, want %q", d.Network, tt.wantNet)
+			}
+			if d.Address != tt.wantAddr {
+				t.Errorf("Address = %q, want %q", d.Address, tt.wantAddr)
+			}
+		})
+	}
+}
+
+func TestVerifyMsgTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldTable map[string]uint16
+		newTable map[string]uint16
+		wantErr  bool
+	}{
+		{
+			name:     "identical tables",
+			oldTable: map[string]uint16{"show_version_13f2a84a": 1},
+			newTable: map[string]uint16{"show_version_13f2a84a": 1, "extra_msg_abcdef01": 2},
+		},
+		{
+			name:     "empty old table",
+			oldTable: map[string]uint16{},
+			newTable: map[string]uint16{"show_version_13f2a84a": 1},
+		},
+		{
+			name:     "message no longer present",
+			oldTable: map[string]uint16{"show_version_13f2a84a": 1},
+			newTable: map[string]uint16{},
+			wantErr:  true,
+		},
+		{
+			name:     "message id changed",
+			oldTable: map[string]uint16{"show_version_13f2a84a": 1},
+			newTable: map[string]uint16{"show_version_13f2a84a": 2},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyMsgTable(tt.oldTable, tt.newTable)
+			if tt.wantErr && err == nil {
+				t.Fatalf("verifyMsgTable() = nil error, want error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("verifyMsgTable() returned unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetPutBuf(t *testing.T) {
+	c := &vppClient{
+		bufPool: &sync.Pool{
+			New: func() interface{} {
+				return make([]byte, 64)
+			},
+		},
+	}
+
+	buf, pooled := c.getBuf(16)
+	if !pooled {
+		t.Fatalf("getBuf(16) pooled = false, want true")
+	}
+	if len(buf) != 16 {
+		t.Fatalf("getBuf(16) len = %d, want 16", len(buf))
+	}
+	if got := c.Stats().PoolHits; got != 1 {
+		t.Fatalf("PoolHits = %d, want 1", got)
+	}
+	c.putBuf(buf, pooled)
+
+	buf2, pooled2 := c.getBuf(16)
+	if !pooled2 {
+		t.Fatalf("getBuf(16) after putBuf pooled = false, want true")
+	}
+	if got := c.Stats().PoolHits; got != 2 {
+		t.Fatalf("PoolHits = %d, want 2", got)
+	}
+	c.putBuf(buf2, pooled2)
+
+	// A request bigger than anything the pool has produced so far still
+	// gets a fresh allocation, but the displaced undersized buffer goes
+	// back to the pool (for some future, smaller message) instead of being
+	// dropped, and the new, bigger one is itself marked pooled so putBuf
+	// feeds it back, growing the pool's capacity toward this message's
+	// size. Use a dedicated pool here (New returning a too-small buffer,
+	// same as c's) so the PoolHits count below is not affected by c's
+	// buffers.
+	grow := &vppClient{
+		bufPool: &sync.Pool{
+			New: func() interface{} {
+				return make([]byte, 64)
+			},
+		},
+	}
+	big, pooledBig := grow.getBuf(1024)
+	if !pooledBig {
+		t.Fatalf("getBuf(1024) pooled = false, want true (oversized request still grows the pool)")
+	}
+	if len(big) != 1024 {
+		t.Fatalf("getBuf(1024) len = %d, want 1024", len(big))
+	}
+	if got := grow.Stats().PoolHits; got != 0 {
+		t.Fatalf("PoolHits = %d, want 0 (oversized request is not a hit)", got)
+	}
+	grow.putBuf(big, pooledBig)
+
+	// Further oversized requests keep being marked pooled, so each
+	// fresh buffer is handed back to the pool by putBuf. sync.Pool does
+	// not guarantee which of its buffers Get returns, so which buffer is
+	// served is not checked here.
+	seenGrown := false
+	for i := 0; i < 2; i++ {
+		b, pooledB := grow.getBuf(1024)
+		if pooledB {
+			seenGrown = true
+		}
+		grow.putBuf(b, pooledB)
+	}
+	if !seenGrown {
+		t.Fatalf("getBuf(1024) never returned pooled = true, so putBuf would not feed its buffer back")
+	}
+
+	// Disabling the pool (nil) always allocates fresh and never pools back.
+	c.bufPool = nil
+	buf3, pooled3 := c.getBuf(8)
+	if pooled3 {
+		t.Fatalf("getBuf(8) with nil pool: pooled = true, want false")
+	}
+	c.putBuf(buf3, pooled3) // must not panic against a nil pool
+}
+
+// TestDiscardPendingReportsEveryRequest exercises the give-up path used by
+// reconnect() when it abandons reconnection: every request still in
+// c.pending must be reported via onReconnectError(ErrReconnected), and
+// c.pending must end up empty.
+func TestDiscardPendingReportsEveryRequest(t *testing.T) {
+	var reported []uint32
+	var mu sync.Mutex
+	c := &vppClient{
+		pending: map[uint32][]byte{
+			1: {0x01},
+			2: {0x02},
+			3: {0x03},
+		},
+		onReconnectError: func(context uint32, err error) {
+			if err != ErrReconnected {
+				t.Errorf("onReconnectError err = %v, want ErrReconnected", err)
+			}
+			mu.Lock()
+			reported = append(reported, context)
+			mu.Unlock()
+		},
+	}
+
+	c.discardPending()
+
+	if len(reported) != 3 {
+		t.Fatalf("reported %d contexts, want 3: %v", len(reported), reported)
+	}
+	c.pendingMu.Lock()
+	pending := c.pending
+	c.pendingMu.Unlock()
+	if pending != nil {
+		t.Fatalf("c.pending = %v, want nil after discardPending", pending)
+	}
+}
+
+// TestDiscardPendingNoHook confirms discardPending still drains c.pending
+// when no onReconnectError hook is installed.
+func TestDiscardPendingNoHook(t *testing.T) {
+	c := &vppClient{
+		pending: map[uint32][]byte{1: {0x01}},
+	}
+	c.discardPending()
+	c.pendingMu.Lock()
+	pending := c.pending
+	c.pendingMu.Unlock()
+	if pending != nil {
+		t.Fatalf("c.pending = %v, want nil after discardPending", pending)
+	}
+}
+
+// TestReplayPendingResend exercises replayPending with ReplayPending=true:
+// the pending request must be re-encoded for the new ClientIndex and
+// rewritten to c.writer, and it must remain tracked in c.pending afterward
+// (ready to be ack'd by the next reply, same as a fresh SendMsg).
+func TestReplayPendingResend(t *testing.T) {
+	var out bytes.Buffer
+	c := &vppClient{
+		reconnectPolicy: &ReconnectPolicy{ReplayPending: true},
+		clientIndex:     42,
+		pending: map[uint32][]byte{
+			7: append([]byte{0x00, 0x00}, make([]byte, 8)...),
+		},
+	}
+	c.writer = bufio.NewWriter(&out)
+
+	c.replayPending()
+
+	if out.Len() == 0 {
+		t.Fatalf("replayPending() wrote nothing to the connection")
+	}
+	// ClientIndex 42 and Context 7, big-endian, as packed by encodeReqHeader.
+	if want := []byte{0, 0, 0, 42, 0, 0, 0, 7}; !bytes.Contains(out.Bytes(), want) {
+		t.Fatalf("replayPending() wrote % X, want it to contain re-encoded header % X", out.Bytes(), want)
+	}
+	c.pendingMu.Lock()
+	_, stillPending := c.pending[7]
+	c.pendingMu.Unlock()
+	if !stillPending {
+		t.Fatalf("replayPending() with ReplayPending=true should re-track the resent request")
+	}
+}
+
+// TestReplayPendingReport exercises replayPending with ReplayPending=false:
+// the pending request must be reported via onReconnectError and dropped,
+// with nothing written to the connection.
+func TestReplayPendingReport(t *testing.T) {
+	var out bytes.Buffer
+	var reportedErr error
+	var reportedCtx uint32
+	c := &vppClient{
+		reconnectPolicy: &ReconnectPolicy{ReplayPending: false},
+		pending: map[uint32][]byte{
+			7: {0x00, 0x00},
+		},
+		onReconnectError: func(context uint32, err error) {
+			reportedCtx = context
+			reportedErr = err
+		},
+	}
+	c.writer = bufio.NewWriter(&out)
+
+	c.replayPending()
+
+	if reportedCtx != 7 || reportedErr != ErrReconnected {
+		t.Fatalf("onReconnectError(%v, %v), want (7, ErrReconnected)", reportedCtx, reportedErr)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("replayPending() with ReplayPending=false wrote %d bytes, want 0", out.Len())
+	}
+	c.pendingMu.Lock()
+	_, stillPending := c.pending[7]
+	c.pendingMu.Unlock()
+	if stillPending {
+		t.Fatalf("replayPending() with ReplayPending=false should drop the reported request")
+	}
+}
+
+// TestDisconnectAfterTerminated exercises Disconnect() against a client
+// left behind by a reconnect that gave up: the connection is already
+// closed and readerLoop has already returned (so wg is already at zero),
+// which previously made Disconnect try to close it a second time and
+// surface a spurious "use of closed connection" error. It must instead be
+// a no-op, and safe to call more than once.
+func TestDisconnectAfterTerminated(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	c := &vppClient{
+		conn:       client,
+		quit:       make(chan struct{}),
+		terminated: true,
+	}
+
+	if err := c.Disconnect(); err != nil {
+		t.Fatalf("Disconnect() after reconnect gave up returned error: %v", err)
+	}
+	if err := c.Disconnect(); err != nil {
+		t.Fatalf("second Disconnect() call returned error: %v", err)
+	}
+}